@@ -2,17 +2,27 @@ package modulecost
 
 import (
 	"bytes"
+	"context"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/google/uuid"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/sync/errgroup"
 )
 
 // Result represents the results of analyzing a Module / GOOS / GOARCH combination
@@ -43,14 +53,118 @@ type Result struct {
 
 	// Cost is the result of subtracting Baseline from WithMod
 	Cost uint64
+
+	// Breakdown holds a per-section and per-package size breakdown of the built binary. It is
+	// only populated when the Analyzer was created with WithBinaryBreakdown, and is left nil
+	// if the binary's format could not be parsed on the host.
+	Breakdown *Breakdown
+
+	// BuildFlags is the effective set of `go build` options used to produce WithMod, so that
+	// downstream tables can distinguish e.g. stripped vs unstripped measurements.
+	BuildFlags BuildFlags
+}
+
+// BuildFlags is the effective set of `go build`-affecting options used to produce a Result.
+type BuildFlags struct {
+	// Tags is the -tags passed to `go build`.
+	Tags []string
+
+	// Ldflags is the -ldflags passed to `go build`.
+	Ldflags string
+
+	// Trimpath indicates whether -trimpath was passed to `go build`.
+	Trimpath bool
+
+	// BuildMode is the -buildmode passed to `go build`.
+	BuildMode string
+
+	// CGO indicates whether CGO_ENABLED=1 was set for this build.
+	CGO bool
+
+	// GCFlags is the -gcflags passed to `go build`.
+	GCFlags string
+
+	// AsmFlags is the -asmflags passed to `go build`.
+	AsmFlags string
+}
+
+// Breakdown is a section- and package-level size breakdown of a built binary.
+type Breakdown struct {
+	// Sections maps section name (e.g. ".text", ".rodata", ".gopclntab") to its size in bytes.
+	Sections map[string]uint64
+
+	// Packages maps package import path to the cumulative size in bytes of the symbols `go
+	// tool nm -size` attributes to it. Attribution is best-effort: it is derived from symbol
+	// name prefixes and may lump closures, generics instantiations, or cgo-generated symbols
+	// in with their enclosing package.
+	Packages map[string]uint64
+}
+
+// DiffResult captures how a module's size cost changed between two versions, for a single GOOS
+// and GOARCH pair.
+type DiffResult struct {
+	// Module is the module path being diffed.
+	Module string
+
+	// GOOS is the GOOS environment variable used for this analysis
+	GOOS string
+
+	// GOARCH is the GOARCH environment variable used for this analysis
+	GOARCH string
+
+	// VersionA is the first version analyzed.
+	VersionA string
+
+	// VersionB is the second version analyzed.
+	VersionB string
+
+	// Baseline is the size in bytes of the baseline (without any modules added) binary
+	Baseline uint64
+
+	// SizeA is the size in bytes of the binary built with the module at VersionA.
+	SizeA uint64
+
+	// SizeB is the size in bytes of the binary built with the module at VersionB.
+	SizeB uint64
+
+	// Delta is SizeB minus SizeA; positive means VersionB costs more.
+	Delta int64
+
+	// SectionDelta maps section name to the change in size (SizeB - SizeA) for that section. It
+	// is only populated when the Analyzer was created with WithBinaryBreakdown.
+	SectionDelta map[string]int64
+
+	// PackageDelta maps package import path to the change in size (SizeB - SizeA) for that
+	// package. It is only populated when the Analyzer was created with WithBinaryBreakdown.
+	PackageDelta map[string]int64
+
+	// ResultA is the full Result analyzing the module at VersionA.
+	ResultA *Result
+
+	// ResultB is the full Result analyzing the module at VersionB.
+	ResultB *Result
+
+	// Error will be nil unless analysis of either version failed; Delta, SectionDelta, and
+	// PackageDelta are meaningless when Error is non-nil.
+	Error error
 }
 
 // Analyzer instances can be used to analyze cost of a matrix of modules under specified GOOS and GOARCH
 type Analyzer struct {
-	workDir string
-	modules []string
-	goos    []string
-	goarch  []string
+	workDir         string
+	modules         []string
+	goos            []string
+	goarch          []string
+	binaryBreakdown bool
+	concurrency     int
+	moduleVersions  map[string]string
+	buildTags       []string
+	ldflags         string
+	trimpath        bool
+	buildMode       string
+	cgo             bool
+	gcflags         string
+	asmflags        string
 }
 
 // Option is used to configure an Analyzer instance
@@ -153,11 +267,105 @@ func WithGOARCHes(goarches []string) Option {
 	}
 }
 
-func validate(a *Analyzer) error {
-	if len(a.modules) == 0 {
-		return errors.New("must provide at least one module to analyze")
+// WithBinaryBreakdown enables deep inspection of each built binary, populating Result.Breakdown
+// with a per-section size breakdown (read via debug/elf, debug/macho, or debug/pe depending on
+// the target GOOS) and a per-package size breakdown (derived from `go tool nm -size`). This adds
+// meaningfully to analysis time since it requires parsing the binary on disk before it is removed.
+func WithBinaryBreakdown() Option {
+	return func(a *Analyzer) error {
+		a.binaryBreakdown = true
+		return nil
+	}
+}
+
+// WithConcurrency sets the number of module/GOOS/GOARCH cells analyzed concurrently. Workers
+// share GOCACHE and GOMODCACHE, so parallel builds benefit from each other's downloaded modules
+// and compiled packages. Defaults to 1 (serial) when unset or n < 1.
+func WithConcurrency(n int) Option {
+	return func(a *Analyzer) error {
+		if n > 0 {
+			a.concurrency = n
+		}
+		return nil
 	}
+}
 
+// WithModuleVersion pins module to version instead of letting `go get` resolve it to @latest.
+// version is written into the generated go.mod via modfile.AddRequire.
+func WithModuleVersion(module string, version string) Option {
+	return func(a *Analyzer) error {
+		if module == "" || version == "" {
+			return nil
+		}
+
+		if a.moduleVersions == nil {
+			a.moduleVersions = map[string]string{}
+		}
+		a.moduleVersions[module] = version
+
+		return nil
+	}
+}
+
+// WithBuildTags adds tags to the -tags passed to `go build` for every analyzed module.
+func WithBuildTags(tags []string) Option {
+	return func(a *Analyzer) error {
+		a.buildTags = append(a.buildTags, tags...)
+		return nil
+	}
+}
+
+// WithLdflags sets the -ldflags passed to `go build`, e.g. "-s -w" to measure a stripped binary.
+func WithLdflags(ldflags string) Option {
+	return func(a *Analyzer) error {
+		a.ldflags = ldflags
+		return nil
+	}
+}
+
+// WithTrimpath toggles the -trimpath flag passed to `go build`.
+func WithTrimpath(trimpath bool) Option {
+	return func(a *Analyzer) error {
+		a.trimpath = trimpath
+		return nil
+	}
+}
+
+// WithBuildMode sets the -buildmode passed to `go build`, e.g. "pie".
+func WithBuildMode(buildMode string) Option {
+	return func(a *Analyzer) error {
+		a.buildMode = buildMode
+		return nil
+	}
+}
+
+// WithCGO toggles CGO_ENABLED for the `go get`/`go build` invocations. Analysis defaults to
+// CGO_ENABLED=0 for reproducible, statically-linked measurements that don't depend on a host C
+// toolchain.
+func WithCGO(cgo bool) Option {
+	return func(a *Analyzer) error {
+		a.cgo = cgo
+		return nil
+	}
+}
+
+// WithGCFlags sets the -gcflags passed to `go build`.
+func WithGCFlags(gcflags string) Option {
+	return func(a *Analyzer) error {
+		a.gcflags = gcflags
+		return nil
+	}
+}
+
+// WithAsmFlags sets the -asmflags passed to `go build`.
+func WithAsmFlags(asmflags string) Option {
+	return func(a *Analyzer) error {
+		a.asmflags = asmflags
+		return nil
+	}
+}
+
+func validate(a *Analyzer) error {
 	if a.workDir == "" {
 		a.workDir = filepath.Join(os.TempDir(), "go-module-cost")
 	}
@@ -170,10 +378,16 @@ func validate(a *Analyzer) error {
 		a.goarch = append(a.goarch, runtime.GOARCH)
 	}
 
+	if a.concurrency < 1 {
+		a.concurrency = 1
+	}
+
 	return nil
 }
 
-// NewAnalyzer will create an instance of Analyzer configured using provided options
+// NewAnalyzer will create an instance of Analyzer configured using provided options. Note that
+// at least one module (via WithModule/WithModules/WithModulesFromGoMod) is only required before
+// calling Analyze; AnalyzeDiff takes its module as an argument and does not need one configured.
 func NewAnalyzer(options ...Option) (*Analyzer, error) {
 	a := &Analyzer{}
 
@@ -190,91 +404,312 @@ func NewAnalyzer(options ...Option) (*Analyzer, error) {
 	return a, nil
 }
 
-// Analyze will perform analysis. An error will be returned if a base size is unable to be calculated for a particular
-// GOOS and GOARCH pair, otherwise any errors during analysis of a particular module/GOOS/GOARCH will be added to the
-// relevant Result
+// Analyze will perform analysis. An error will be returned if no module was configured via
+// WithModule/WithModules/WithModulesFromGoMod, or if a base size is unable to be calculated for a
+// particular GOOS and GOARCH pair; otherwise any errors during analysis of a particular
+// module/GOOS/GOARCH will be added to the relevant Result. Cells (module/GOOS/GOARCH combinations)
+// are analyzed across up to a.concurrency goroutines, but results are always returned in the same
+// deterministic GOOS-major, then GOARCH, then module order regardless of completion order.
 func (a *Analyzer) Analyze() ([]*Result, error) {
-	baseSizes := map[string]uint64{}
-	// Calculate base sizes
+	if len(a.modules) == 0 {
+		return nil, errors.New("must provide at least one module to analyze")
+	}
+
+	baseSizes, err := a.calcBaseSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	type cell struct {
+		module string
+		goos   string
+		goarch string
+	}
+
+	var cells []cell
 	for _, goos := range a.goos {
 		for _, goarch := range a.goarch {
-			baseSize, err := a.calcBytes(filepath.Join(a.workDir, "base"), "", goos, goarch)
-			if err != nil {
-				return nil, err
+			for _, module := range a.modules {
+				cells = append(cells, cell{module: module, goos: goos, goarch: goarch})
 			}
-			baseSizes[fmt.Sprintf("%s:%s", goos, goarch)] = baseSize
 		}
 	}
 
-	var results []*Result
+	results := make([]*Result, len(cells))
 
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, a.concurrency)
+
+	for i, c := range cells {
+		i, c := i, c
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := a.analyzeModule(c.module, c.goos, c.goarch)
+			if err != nil {
+				// TODO: Add logging
+				results[i] = result
+				return
+			}
+
+			baseSize := baseSizes[fmt.Sprintf("%s:%s", c.goos, c.goarch)]
+			result.Baseline = baseSize
+			result.Cost = result.WithMod - baseSize
+			results[i] = result
+		}()
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// AnalyzeDiff analyzes module at versionA and versionB across the Analyzer's configured
+// GOOS/GOARCH matrix and returns, for each GOOS/GOARCH pair, a DiffResult describing how the
+// module's cost changed between the two versions. Unlike Analyze, it does not require the
+// Analyzer to have been configured with any module via WithModule/WithModules/
+// WithModulesFromGoMod; module is taken from the argument, and any a.modules is ignored. When the
+// Analyzer was created with WithBinaryBreakdown, the DiffResult also carries a per-section and
+// per-package delta derived from both versions' breakdowns.
+func (a *Analyzer) AnalyzeDiff(module string, versionA string, versionB string) ([]*DiffResult, error) {
+	baseSizes, err := a.calcBaseSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	type cell struct {
+		goos   string
+		goarch string
+	}
+
+	var cells []cell
 	for _, goos := range a.goos {
 		for _, goarch := range a.goarch {
-			for _, module := range a.modules {
-				result, err := a.analyzeModule(module, goos, goarch)
-				if err != nil {
-					// TODO: Add logging
-					results = append(results, result)
-					continue
-				}
-				baseSize := baseSizes[fmt.Sprintf("%s:%s", goos, goarch)]
-				result.Baseline = baseSize
-				result.Cost = result.WithMod - baseSize
-				results = append(results, result)
-			}
+			cells = append(cells, cell{goos: goos, goarch: goarch})
 		}
 	}
 
+	results := make([]*DiffResult, len(cells))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, a.concurrency)
+
+	for i, c := range cells {
+		i, c := i, c
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			baseSize := baseSizes[fmt.Sprintf("%s:%s", c.goos, c.goarch)]
+			results[i] = a.analyzeDiffCell(module, versionA, versionB, c.goos, c.goarch, baseSize)
+		}()
+	}
+
+	wg.Wait()
+
 	return results, nil
 }
 
+func (a *Analyzer) analyzeDiffCell(module string, versionA string, versionB string, goos string, goarch string, baseSize uint64) *DiffResult {
+	result := &DiffResult{
+		Module:   module,
+		GOOS:     goos,
+		GOARCH:   goarch,
+		VersionA: versionA,
+		VersionB: versionB,
+		Baseline: baseSize,
+	}
+
+	resultA, errA := a.analyzeModuleVersion(module, versionA, goos, goarch)
+	result.ResultA = resultA
+	if errA != nil {
+		result.Error = errA
+		return result
+	}
+	resultA.Baseline = baseSize
+	resultA.Cost = resultA.WithMod - baseSize
+
+	resultB, errB := a.analyzeModuleVersion(module, versionB, goos, goarch)
+	result.ResultB = resultB
+	if errB != nil {
+		result.Error = errB
+		return result
+	}
+	resultB.Baseline = baseSize
+	resultB.Cost = resultB.WithMod - baseSize
+
+	result.SizeA = resultA.WithMod
+	result.SizeB = resultB.WithMod
+	result.Delta = int64(resultB.WithMod) - int64(resultA.WithMod)
+
+	if resultA.Breakdown != nil && resultB.Breakdown != nil {
+		result.SectionDelta = diffSizeMaps(resultA.Breakdown.Sections, resultB.Breakdown.Sections)
+		result.PackageDelta = diffSizeMaps(resultA.Breakdown.Packages, resultB.Breakdown.Packages)
+	}
+
+	return result
+}
+
+// diffSizeMaps returns a map of every key present in either a or b to the delta (b[key] -
+// a[key]) between them.
+func diffSizeMaps(a map[string]uint64, b map[string]uint64) map[string]int64 {
+	delta := make(map[string]int64, len(a)+len(b))
+	for k, v := range a {
+		delta[k] -= int64(v)
+	}
+	for k, v := range b {
+		delta[k] += int64(v)
+	}
+	return delta
+}
+
+// calcBaseSizes computes the baseline (no module added) binary size for every GOOS/GOARCH pair
+// in the matrix, fanning the builds out across up to a.concurrency goroutines. The first fatal
+// error cancels the shared context so workers that haven't started yet are skipped; in-flight
+// `go build` invocations still run to completion since they don't accept a context.
+func (a *Analyzer) calcBaseSizes() (map[string]uint64, error) {
+	type baseCell struct {
+		goos   string
+		goarch string
+	}
+
+	var cells []baseCell
+	for _, goos := range a.goos {
+		for _, goarch := range a.goarch {
+			cells = append(cells, baseCell{goos: goos, goarch: goarch})
+		}
+	}
+
+	sizes := make([]uint64, len(cells))
+
+	g, ctx := errgroup.WithContext(context.Background())
+	sem := make(chan struct{}, a.concurrency)
+
+cellLoop:
+	for i, c := range cells {
+		i, c := i, c
+
+		select {
+		case <-ctx.Done():
+			break cellLoop
+		case sem <- struct{}{}:
+		}
+
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			workDir := filepath.Join(a.workDir, "base", fmt.Sprintf("%s-%s", c.goos, c.goarch))
+			size, err := a.calcBytes(workDir, "", "", c.goos, c.goarch)
+			if err != nil {
+				return err
+			}
+
+			sizes[i] = size
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	baseSizes := make(map[string]uint64, len(cells))
+	for i, c := range cells {
+		baseSizes[fmt.Sprintf("%s:%s", c.goos, c.goarch)] = sizes[i]
+	}
+
+	return baseSizes, nil
+}
+
 func (a *Analyzer) analyzeModule(module string, goos string, goarch string) (*Result, error) {
+	return a.analyzeModuleVersion(module, a.moduleVersions[module], goos, goarch)
+}
+
+// analyzeModuleVersion analyzes module at version (an explicit version always wins over any
+// version configured via WithModuleVersion, so AnalyzeDiff can pin two versions of the same
+// module independently of the Analyzer's own configuration).
+func (a *Analyzer) analyzeModuleVersion(module string, version string, goos string, goarch string) (*Result, error) {
 	start := time.Now()
 
-	workDir := filepath.Join(a.workDir, path.Base(module))
+	// A per-cell UUID subdirectory avoids collisions between two modules that share a last path
+	// element (e.g. "example.com/foo/client" and "example.org/bar/client"), which path.Base
+	// would otherwise map to the same workDir.
+	workDir := filepath.Join(a.workDir, uuid.NewString())
 	defer func() {
 		if err := os.RemoveAll(workDir); err != nil {
 			fmt.Printf("Unable to remove '%s': %s\n", workDir, err)
 		}
 	}()
 
-	modSize, err := a.calcBytes(filepath.Join(workDir, "mod"), module, goos, goarch)
+	buildFlags := BuildFlags{
+		Tags:      a.buildTags,
+		Ldflags:   a.ldflags,
+		Trimpath:  a.trimpath,
+		BuildMode: a.buildMode,
+		CGO:       a.cgo,
+		GCFlags:   a.gcflags,
+		AsmFlags:  a.asmflags,
+	}
+
+	modSize, err := a.calcBytes(filepath.Join(workDir, "mod"), module, version, goos, goarch)
 
 	// See if any error occurred calculating bytes
 	if err != nil {
 		return &Result{
-			Duration: time.Since(start),
-			Module:   module,
-			GOOS:     goos,
-			GOARCH:   goarch,
-			Error:    err,
+			Duration:   time.Since(start),
+			Module:     module,
+			GOOS:       goos,
+			GOARCH:     goarch,
+			Error:      err,
+			BuildFlags: buildFlags,
 		}, err
 	}
 
 	modPath, modVersion, err := versionFromModFile(filepath.Join(workDir, "mod", "go.mod"), module)
 	if err != nil {
 		return &Result{
-			Duration: time.Since(start),
-			Module:   module,
-			GOOS:     goos,
-			GOARCH:   goarch,
-			Error:    err,
+			Duration:   time.Since(start),
+			Module:     module,
+			GOOS:       goos,
+			GOARCH:     goarch,
+			Error:      err,
+			BuildFlags: buildFlags,
 		}, err
 	}
 
+	var breakdown *Breakdown
+	if a.binaryBreakdown {
+		// Best-effort: a binary the host can't parse (e.g. a foreign-arch Mach-O on a Linux
+		// host without cgo support) just means no breakdown, not a failed analysis.
+		breakdown, _ = binaryBreakdown(filepath.Join(workDir, "mod"), goos)
+	}
+
 	return &Result{
-		Duration: time.Since(start),
-		Module:   modPath,
-		Version:  modVersion,
-		GOOS:     goos,
-		GOARCH:   goarch,
-		WithMod:  modSize,
+		Duration:   time.Since(start),
+		Module:     modPath,
+		Version:    modVersion,
+		GOOS:       goos,
+		GOARCH:     goarch,
+		WithMod:    modSize,
+		Breakdown:  breakdown,
+		BuildFlags: buildFlags,
 	}, nil
 }
 
-func (a *Analyzer) calcBytes(workDir string, module string, goos string, goarch string) (uint64, error) {
+func (a *Analyzer) calcBytes(workDir string, module string, version string, goos string, goarch string) (uint64, error) {
 	// Build the directory
-	if err := buildModuleDir(workDir, module); err != nil {
+	if err := buildModuleDir(workDir, module, version); err != nil {
 		return 0, err
 	}
 
@@ -287,25 +722,57 @@ func (a *Analyzer) calcBytes(workDir string, module string, goos string, goarch
 	return binBytes(workDir)
 }
 
+// buildArgs assembles the `go build` arguments reflecting the Analyzer's configured build-option
+// knobs (WithBuildTags, WithLdflags, WithTrimpath, WithBuildMode, WithGCFlags, WithAsmFlags).
+func (a *Analyzer) buildArgs() []string {
+	args := []string{"build", "-o", fmt.Sprintf("bin%s", os.Getenv("GOEXE"))}
+	if a.trimpath {
+		args = append(args, "-trimpath")
+	}
+	if a.buildMode != "" {
+		args = append(args, "-buildmode", a.buildMode)
+	}
+	if len(a.buildTags) > 0 {
+		args = append(args, "-tags", strings.Join(a.buildTags, ","))
+	}
+	if a.ldflags != "" {
+		args = append(args, "-ldflags", a.ldflags)
+	}
+	if a.gcflags != "" {
+		args = append(args, "-gcflags", a.gcflags)
+	}
+	if a.asmflags != "" {
+		args = append(args, "-asmflags", a.asmflags)
+	}
+	args = append(args, ".")
+
+	return args
+}
+
 func (a *Analyzer) buildBin(workDir string, goos string, goarch string) error {
-	gg := exec.Command("go", "get", "./...")
-	gg.Dir = workDir
-	gg.Env = append(os.Environ(),
-		"CGO_ENABLED=0",
+	cgoEnabled := "0"
+	if a.cgo {
+		cgoEnabled = "1"
+	}
+
+	env := append(os.Environ(),
+		fmt.Sprintf("CGO_ENABLED=%s", cgoEnabled),
 		fmt.Sprintf("GOOS=%s", goos),
 		fmt.Sprintf("GOARCH=%s", goarch),
 	)
+
+	gg := exec.Command("go", "get", "./...")
+	gg.Dir = workDir
+	gg.Env = env
 	if err := gg.Run(); err != nil {
 		return err
 	}
 
-	bc := exec.Command("go", "build", "-o", fmt.Sprintf("bin%s", os.Getenv("GOEXE")), ".")
+	args := a.buildArgs()
+
+	bc := exec.Command("go", args...)
 	bc.Dir = workDir
-	bc.Env = append(os.Environ(),
-		"CGO_ENABLED=0",
-		fmt.Sprintf("GOOS=%s", goos),
-		fmt.Sprintf("GOARCH=%s", goarch),
-	)
+	bc.Env = env
 	if err := bc.Run(); err != nil {
 		return err
 	}
@@ -313,8 +780,8 @@ func (a *Analyzer) buildBin(workDir string, goos string, goarch string) error {
 	return nil
 }
 
-func buildModuleDir(workDir string, module string) error {
-	mod, err := modFile(module)
+func buildModuleDir(workDir string, module string, version string) error {
+	mod, err := modFile(module, version)
 	if err != nil {
 		return err
 	}
@@ -341,10 +808,10 @@ func buildModuleDir(workDir string, module string) error {
 	return nil
 }
 
-func modFile(module string) ([]byte, error) {
+func modFile(module string, version string) ([]byte, error) {
 	mf := &modfile.File{}
 
-	moduleName := "github.com/tehbilly/go-module-analyzer"
+	moduleName := "github.com/tehbilly/go-module-cost"
 	if module != "" {
 		moduleName = moduleName + "/" + path.Base(module)
 	}
@@ -353,6 +820,12 @@ func modFile(module string) ([]byte, error) {
 		return nil, err
 	}
 
+	if module != "" && version != "" {
+		if err := mf.AddRequire(module, version); err != nil {
+			return nil, err
+		}
+	}
+
 	return mf.Format()
 }
 
@@ -410,3 +883,164 @@ func binBytes(workDir string) (uint64, error) {
 
 	return uint64(fi.Size()), nil
 }
+
+// binaryBreakdown opens the binary built in modWorkDir and returns its section and package size
+// breakdown. goos selects which debug/* reader to use.
+func binaryBreakdown(modWorkDir string, goos string) (*Breakdown, error) {
+	binPath := filepath.Join(modWorkDir, fmt.Sprintf("bin%s", os.Getenv("GOEXE")))
+
+	sections, err := sectionSizes(binPath, goos)
+	if err != nil {
+		return nil, err
+	}
+
+	packages, err := packageSizes(modWorkDir, binPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Breakdown{Sections: sections, Packages: packages}, nil
+}
+
+// sectionSizes opens binPath with the debug/* package appropriate for goos and returns a map of
+// section name to size in bytes.
+func sectionSizes(binPath string, goos string) (map[string]uint64, error) {
+	switch goos {
+	case "darwin", "ios":
+		return machoSectionSizes(binPath)
+	case "windows":
+		return peSectionSizes(binPath)
+	default:
+		return elfSectionSizes(binPath)
+	}
+}
+
+func elfSectionSizes(binPath string) (map[string]uint64, error) {
+	f, err := elf.Open(binPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sizes := make(map[string]uint64)
+	for _, sec := range f.Sections {
+		if sec.Size == 0 {
+			continue
+		}
+		sizes[sec.Name] += sec.Size
+	}
+
+	return sizes, nil
+}
+
+// machoSectionSizes handles both plain Mach-O binaries and universal (fat) binaries, summing
+// section sizes across all architectures slices in the latter case.
+func machoSectionSizes(binPath string) (map[string]uint64, error) {
+	sizes := make(map[string]uint64)
+
+	if fat, err := macho.OpenFat(binPath); err == nil {
+		defer fat.Close()
+
+		for _, arch := range fat.Arches {
+			for _, sec := range arch.Sections {
+				if sec.Size == 0 {
+					continue
+				}
+				sizes[sec.Name] += uint64(sec.Size)
+			}
+		}
+
+		return sizes, nil
+	}
+
+	f, err := macho.Open(binPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	for _, sec := range f.Sections {
+		if sec.Size == 0 {
+			continue
+		}
+		sizes[sec.Name] += uint64(sec.Size)
+	}
+
+	return sizes, nil
+}
+
+// peSectionSizes returns PE section sizes, including PDATA (the exception/unwind table section
+// on amd64/arm64) which is reported like any other section.
+func peSectionSizes(binPath string) (map[string]uint64, error) {
+	f, err := pe.Open(binPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sizes := make(map[string]uint64)
+	for _, sec := range f.Sections {
+		if sec.Size == 0 {
+			continue
+		}
+		sizes[sec.Name] += uint64(sec.Size)
+	}
+
+	return sizes, nil
+}
+
+// packageSizes runs `go tool nm -size` against binPath and sums symbol sizes per attributed
+// package import path.
+func packageSizes(dir string, binPath string) (map[string]uint64, error) {
+	nm := exec.Command("go", "tool", "nm", "-size", binPath)
+	nm.Dir = dir
+
+	out, err := nm.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running go tool nm: %w", err)
+	}
+
+	sizes := make(map[string]uint64)
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		sizes[packageFromSymbol(fields[3])] += size
+	}
+
+	return sizes, nil
+}
+
+// versionedFinalSegment matches a final path segment of the form "name.vN" (e.g. "yaml.v2"),
+// the "gopkg.in" convention for encoding a major version in the last path element, followed by
+// the symbol name.
+var versionedFinalSegment = regexp.MustCompile(`^([A-Za-z0-9_-]+)\.(v[0-9]+)\.(.+)$`)
+
+// packageFromSymbol derives a package import path from a `go tool nm` symbol name. Symbol names
+// are of the form "<import/path>.<symbol>" (optionally with a receiver, e.g.
+// "<import/path>.(*Type).Method"), so the package path is everything up to the first "." that
+// follows the final "/". This naive split breaks when the final path segment itself contains a
+// dot, as with gopkg.in's "name.vN" version-suffix convention (e.g. "gopkg.in/yaml.v2"), so that
+// case is special-cased first.
+func packageFromSymbol(symbol string) string {
+	slash := strings.LastIndex(symbol, "/")
+	rest := symbol[slash+1:]
+
+	if m := versionedFinalSegment.FindStringSubmatch(rest); m != nil {
+		return symbol[:slash+1] + m[1] + "." + m[2]
+	}
+
+	dot := strings.Index(rest, ".")
+	if dot < 0 {
+		return symbol
+	}
+
+	return symbol[:slash+1+dot]
+}
@@ -5,15 +5,10 @@ import (
 	"os"
 	"testing"
 
-	"github.com/dustin/go-humanize"
-	"github.com/olekukonko/tablewriter"
 	modulecost "github.com/tehbilly/go-module-cost"
 )
 
 func TestAnalyzer(t *testing.T) {
-	tw := tablewriter.NewWriter(os.Stdout)
-	tw.SetHeader([]string{"package", "version", "goos", "goarch", "duration", "cost"})
-
 	type testCase struct {
 		module  string
 		options []modulecost.Option
@@ -29,6 +24,8 @@ func TestAnalyzer(t *testing.T) {
 		}},
 	}
 
+	var results []*modulecost.Result
+
 	for _, tc := range testCases {
 		a, err := modulecost.NewAnalyzer(tc.options...)
 		if err != nil {
@@ -37,23 +34,72 @@ func TestAnalyzer(t *testing.T) {
 			return
 		}
 
-		if r, err := a.Analyze(); err != nil {
+		r, err := a.Analyze()
+		if err != nil {
 			fmt.Printf("Error running a.CostInBytes(): %s\n", err)
 			t.Fail()
 			return
-		} else {
-			for _, result := range r {
-				tw.Append([]string{
-					result.Module,
-					result.Version,
-					result.GOOS,
-					result.GOARCH,
-					fmt.Sprint(result.Duration),
-					humanize.Bytes(result.Cost),
-				})
-			}
 		}
+
+		results = append(results, r...)
+	}
+
+	if err := modulecost.Reporters["table"].Report(os.Stdout, results); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestAnalyzeRequiresModule verifies that NewAnalyzer can be constructed without any module
+// configured (as AnalyzeDiff callers need to, since AnalyzeDiff takes its module as an argument),
+// but that Analyze itself still rejects a missing module.
+func TestAnalyzeRequiresModule(t *testing.T) {
+	a, err := modulecost.NewAnalyzer(modulecost.WithGOOS("linux"))
+	if err != nil {
+		t.Fatalf("NewAnalyzer with no module should succeed: %s", err)
 	}
 
-	tw.Render()
+	if _, err := a.Analyze(); err == nil {
+		t.Error("expected Analyze to fail when no module was configured")
+	}
+}
+
+// BenchmarkAnalyzeConcurrency compares wall-clock time analyzing a matrix of ~10 modules x 3
+// GOOSes serially (concurrency=1) against fanning the cells out across 4 workers. Run with:
+//
+//	go test -bench=BenchmarkAnalyzeConcurrency -benchtime=1x -run=^$
+func BenchmarkAnalyzeConcurrency(b *testing.B) {
+	modules := []string{
+		"github.com/dave/jennifer",
+		"github.com/dustin/go-humanize",
+		"github.com/olekukonko/tablewriter",
+		"github.com/mattn/go-runewidth",
+		"golang.org/x/xerrors",
+		"golang.org/x/mod",
+		"golang.org/x/sync",
+		"github.com/google/uuid",
+		"github.com/pkg/errors",
+		"gopkg.in/yaml.v2",
+	}
+	gooses := []string{"linux", "darwin", "windows"}
+
+	for _, concurrency := range []int{1, 4} {
+		concurrency := concurrency
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				a, err := modulecost.NewAnalyzer(
+					modulecost.WithModules(modules),
+					modulecost.WithGOOSes(gooses),
+					modulecost.WithConcurrency(concurrency),
+					modulecost.WithWorkDir(b.TempDir()),
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := a.Analyze(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
@@ -0,0 +1,23 @@
+package modulecost
+
+import "testing"
+
+func TestPackageFromSymbol(t *testing.T) {
+	testCases := []struct {
+		symbol string
+		want   string
+	}{
+		{symbol: "fmt.Println", want: "fmt"},
+		{symbol: "github.com/dave/jennifer/jen.(*Statement).Call", want: "github.com/dave/jennifer/jen"},
+		{symbol: "golang.org/x/mod/modfile.Parse", want: "golang.org/x/mod/modfile"},
+		{symbol: "gopkg.in/yaml.v2.Unmarshal", want: "gopkg.in/yaml.v2"},
+		{symbol: "gopkg.in/check.v1.(*C).Fatalf", want: "gopkg.in/check.v1"},
+		{symbol: "type:*uint8", want: "type:*uint8"},
+	}
+
+	for _, tc := range testCases {
+		if got := packageFromSymbol(tc.symbol); got != tc.want {
+			t.Errorf("packageFromSymbol(%q) = %q, want %q", tc.symbol, got, tc.want)
+		}
+	}
+}
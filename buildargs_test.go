@@ -0,0 +1,49 @@
+package modulecost
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzerBuildArgs(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    Analyzer
+		want []string
+	}{
+		{
+			name: "defaults",
+			a:    Analyzer{},
+			want: []string{"build", "-o", "bin", "."},
+		},
+		{
+			name: "all flags set",
+			a: Analyzer{
+				buildTags: []string{"netgo", "osusergo"},
+				ldflags:   "-s -w",
+				trimpath:  true,
+				buildMode: "pie",
+				gcflags:   "-m",
+				asmflags:  "-trimpath=foo",
+			},
+			want: []string{
+				"build", "-o", "bin",
+				"-trimpath",
+				"-buildmode", "pie",
+				"-tags", "netgo,osusergo",
+				"-ldflags", "-s -w",
+				"-gcflags", "-m",
+				"-asmflags", "-trimpath=foo",
+				".",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.buildArgs(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("buildArgs() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package modulecost
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSizeMaps(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    map[string]uint64
+		b    map[string]uint64
+		want map[string]int64
+	}{
+		{
+			name: "shared key grows",
+			a:    map[string]uint64{".text": 100},
+			b:    map[string]uint64{".text": 150},
+			want: map[string]int64{".text": 50},
+		},
+		{
+			name: "shared key shrinks",
+			a:    map[string]uint64{".text": 150},
+			b:    map[string]uint64{".text": 100},
+			want: map[string]int64{".text": -50},
+		},
+		{
+			name: "key only in a is a negative delta",
+			a:    map[string]uint64{".rodata": 100},
+			b:    map[string]uint64{},
+			want: map[string]int64{".rodata": -100},
+		},
+		{
+			name: "key only in b is a positive delta",
+			a:    map[string]uint64{},
+			b:    map[string]uint64{".rodata": 100},
+			want: map[string]int64{".rodata": 100},
+		},
+		{
+			name: "both empty",
+			a:    map[string]uint64{},
+			b:    map[string]uint64{},
+			want: map[string]int64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := diffSizeMaps(tc.a, tc.b); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("diffSizeMaps(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package modulecost
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Reporter renders a slice of Results to w.
+type Reporter interface {
+	Report(w io.Writer, results []*Result) error
+}
+
+// Reporters is a registry of the built-in Reporter implementations, keyed by name.
+var Reporters = map[string]Reporter{
+	"table":    TableReporter{},
+	"csv":      CSVReporter{},
+	"json":     JSONReporter{},
+	"markdown": MarkdownReporter{},
+}
+
+// HumanizeBytes formats a byte count the way Result's Baseline/WithMod/Cost fields are meant to
+// be displayed, e.g. "1.2 MB".
+func HumanizeBytes(bytes uint64) string {
+	return humanize.Bytes(bytes)
+}
+
+// FormatBuildFlags renders a BuildFlags as a compact, single-line summary (e.g. `ldflags="-s -w"
+// trimpath`) suitable for reporter output, omitting anything left at its zero value.
+func FormatBuildFlags(bf BuildFlags) string {
+	var parts []string
+
+	if len(bf.Tags) > 0 {
+		parts = append(parts, fmt.Sprintf("tags=%s", strings.Join(bf.Tags, ",")))
+	}
+	if bf.Ldflags != "" {
+		parts = append(parts, fmt.Sprintf("ldflags=%q", bf.Ldflags))
+	}
+	if bf.Trimpath {
+		parts = append(parts, "trimpath")
+	}
+	if bf.BuildMode != "" {
+		parts = append(parts, fmt.Sprintf("buildmode=%s", bf.BuildMode))
+	}
+	if bf.CGO {
+		parts = append(parts, "cgo")
+	}
+	if bf.GCFlags != "" {
+		parts = append(parts, fmt.Sprintf("gcflags=%q", bf.GCFlags))
+	}
+	if bf.AsmFlags != "" {
+		parts = append(parts, fmt.Sprintf("asmflags=%q", bf.AsmFlags))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// errString returns r.Error.Error(), or "" when r.Error is nil. A failed Result's Baseline/
+// WithMod/Cost are meaningless zero values, so every reporter surfaces this alongside them rather
+// than rendering a failed row indistinguishably from a real zero-cost module.
+func errString(r *Result) string {
+	if r.Error == nil {
+		return ""
+	}
+	return r.Error.Error()
+}
+
+// TableReporter renders results as a pretty, human-readable table.
+type TableReporter struct{}
+
+// Report implements Reporter.
+func (TableReporter) Report(w io.Writer, results []*Result) error {
+	tw := tablewriter.NewWriter(w)
+	tw.SetHeader([]string{"module", "version", "goos", "goarch", "duration", "cost", "build flags", "error"})
+
+	for _, r := range results {
+		tw.Append([]string{
+			r.Module,
+			r.Version,
+			r.GOOS,
+			r.GOARCH,
+			fmt.Sprint(r.Duration),
+			HumanizeBytes(r.Cost),
+			FormatBuildFlags(r.BuildFlags),
+			errString(r),
+		})
+	}
+
+	tw.Render()
+
+	return nil
+}
+
+// CSVReporter renders results as CSV with a header row.
+type CSVReporter struct{}
+
+// Report implements Reporter.
+func (CSVReporter) Report(w io.Writer, results []*Result) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"module", "version", "goos", "goarch", "duration", "baseline", "with_mod", "cost", "build_flags", "error"}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		err := cw.Write([]string{
+			r.Module,
+			r.Version,
+			r.GOOS,
+			r.GOARCH,
+			r.Duration.String(),
+			strconv.FormatUint(r.Baseline, 10),
+			strconv.FormatUint(r.WithMod, 10),
+			strconv.FormatUint(r.Cost, 10),
+			FormatBuildFlags(r.BuildFlags),
+			errString(r),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// jsonBuildFlags is the stable JSON shape for BuildFlags, kept separate for the same reason as
+// jsonResult.
+type jsonBuildFlags struct {
+	Tags      []string `json:"tags,omitempty"`
+	Ldflags   string   `json:"ldflags,omitempty"`
+	Trimpath  bool     `json:"trimpath,omitempty"`
+	BuildMode string   `json:"build_mode,omitempty"`
+	CGO       bool     `json:"cgo,omitempty"`
+	GCFlags   string   `json:"gcflags,omitempty"`
+	AsmFlags  string   `json:"asmflags,omitempty"`
+}
+
+// jsonResult is the stable, machine-consumable shape JSONReporter emits for each Result. It is
+// kept separate from Result so that internal additions to Result (e.g. Breakdown) don't
+// silently change the JSON a CI job diffs against.
+type jsonResult struct {
+	Module     string         `json:"module"`
+	Version    string         `json:"version"`
+	GOOS       string         `json:"goos"`
+	GOARCH     string         `json:"goarch"`
+	Duration   string         `json:"duration"`
+	Baseline   uint64         `json:"baseline"`
+	WithMod    uint64         `json:"with_mod"`
+	Cost       uint64         `json:"cost"`
+	BuildFlags jsonBuildFlags `json:"build_flags"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// JSONReporter renders results as a JSON array with stable field names, suitable for machine
+// consumption and CI regression diffing.
+type JSONReporter struct{}
+
+// Report implements Reporter.
+func (JSONReporter) Report(w io.Writer, results []*Result) error {
+	out := make([]jsonResult, len(results))
+	for i, r := range results {
+		out[i] = jsonResult{
+			Module:   r.Module,
+			Version:  r.Version,
+			GOOS:     r.GOOS,
+			GOARCH:   r.GOARCH,
+			Duration: r.Duration.String(),
+			Baseline: r.Baseline,
+			WithMod:  r.WithMod,
+			Cost:     r.Cost,
+			BuildFlags: jsonBuildFlags{
+				Tags:      r.BuildFlags.Tags,
+				Ldflags:   r.BuildFlags.Ldflags,
+				Trimpath:  r.BuildFlags.Trimpath,
+				BuildMode: r.BuildFlags.BuildMode,
+				CGO:       r.BuildFlags.CGO,
+				GCFlags:   r.BuildFlags.GCFlags,
+				AsmFlags:  r.BuildFlags.AsmFlags,
+			},
+		}
+		if r.Error != nil {
+			out[i].Error = r.Error.Error()
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(out)
+}
+
+// MarkdownReporter renders results as a GitHub-flavored Markdown table, suitable for posting
+// module-cost regressions as a PR comment.
+type MarkdownReporter struct{}
+
+// Report implements Reporter.
+func (MarkdownReporter) Report(w io.Writer, results []*Result) error {
+	if _, err := fmt.Fprintln(w, "| module | version | goos | goarch | duration | cost | build flags | error |"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "|---|---|---|---|---|---|---|---|"); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		_, err := fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			r.Module, r.Version, r.GOOS, r.GOARCH, r.Duration, HumanizeBytes(r.Cost), FormatBuildFlags(r.BuildFlags), errString(r))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package modulecost
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testResults() []*Result {
+	return []*Result{
+		{
+			Module:   "github.com/example/foo",
+			Version:  "v1.2.3",
+			GOOS:     "linux",
+			GOARCH:   "amd64",
+			Duration: 250 * time.Millisecond,
+			Baseline: 1000,
+			WithMod:  1500,
+			Cost:     500,
+			BuildFlags: BuildFlags{
+				Ldflags:  "-s -w",
+				Trimpath: true,
+			},
+		},
+	}
+}
+
+func testFailedResults() []*Result {
+	return []*Result{
+		{
+			Module: "github.com/example/broken",
+			GOOS:   "linux",
+			GOARCH: "amd64",
+			Error:  errors.New("exit status 1"),
+		},
+	}
+}
+
+func TestCSVReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CSVReporter{}).Report(&buf, testResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "module,version,goos,goarch,duration,baseline,with_mod,cost,build_flags,error") {
+		t.Errorf("missing CSV header, got: %s", out)
+	}
+	if !strings.Contains(out, "github.com/example/foo,v1.2.3,linux,amd64") {
+		t.Errorf("missing expected row, got: %s", out)
+	}
+	if !strings.Contains(out, `"ldflags=""-s -w"" trimpath"`) {
+		t.Errorf("missing build flags, got: %s", out)
+	}
+}
+
+func TestCSVReporterError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CSVReporter{}).Report(&buf, testFailedResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "exit status 1") {
+		t.Errorf("expected failed row to surface its error, got: %s", out)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Report(&buf, testResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`"module": "github.com/example/foo"`, `"cost": 500`, `"trimpath": true`, `"ldflags": "-s -w"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected JSON output to contain %q, got: %s", want, buf.String())
+		}
+	}
+}
+
+func TestJSONReporterError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Report(&buf, testFailedResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"error": "exit status 1"`) {
+		t.Errorf("expected JSON output to contain the error, got: %s", buf.String())
+	}
+}
+
+func TestMarkdownReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (MarkdownReporter{}).Report(&buf, testResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "| module | version | goos | goarch | duration | cost | build flags | error |\n") {
+		t.Errorf("missing markdown header, got: %s", out)
+	}
+	if !strings.Contains(out, "| github.com/example/foo | v1.2.3 | linux | amd64 |") {
+		t.Errorf("missing expected row, got: %s", out)
+	}
+	if !strings.Contains(out, `ldflags="-s -w" trimpath`) {
+		t.Errorf("missing build flags, got: %s", out)
+	}
+}
+
+func TestMarkdownReporterError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (MarkdownReporter{}).Report(&buf, testFailedResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "exit status 1") {
+		t.Errorf("expected failed row to surface its error, got: %s", buf.String())
+	}
+}
+
+func TestTableReporterError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TableReporter{}).Report(&buf, testFailedResults()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "exit status 1") {
+		t.Errorf("expected failed row to surface its error, got: %s", buf.String())
+	}
+}
+
+func TestFormatBuildFlags(t *testing.T) {
+	testCases := []struct {
+		name string
+		bf   BuildFlags
+		want string
+	}{
+		{name: "zero value", bf: BuildFlags{}, want: ""},
+		{
+			name: "all set",
+			bf: BuildFlags{
+				Tags:      []string{"netgo", "osusergo"},
+				Ldflags:   "-s -w",
+				Trimpath:  true,
+				BuildMode: "pie",
+				CGO:       true,
+				GCFlags:   "-m",
+				AsmFlags:  "-trimpath=foo",
+			},
+			want: `tags=netgo,osusergo ldflags="-s -w" trimpath buildmode=pie cgo gcflags="-m" asmflags="-trimpath=foo"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := FormatBuildFlags(tc.bf); got != tc.want {
+			t.Errorf("FormatBuildFlags(%+v) = %q, want %q", tc.bf, got, tc.want)
+		}
+	}
+}
+
+func TestReportersRegistry(t *testing.T) {
+	for _, name := range []string{"table", "csv", "json", "markdown"} {
+		if _, ok := Reporters[name]; !ok {
+			t.Errorf("expected Reporters to contain %q", name)
+		}
+	}
+}